@@ -0,0 +1,28 @@
+package errdefs // import "github.com/docker/docker/api/errdefs"
+
+// ErrNotFound signals that the requested object doesn't exist.
+type ErrNotFound interface {
+	NotFound()
+}
+
+// ErrInvalidParameter signals that the user input is invalid.
+type ErrInvalidParameter interface {
+	InvalidParameter()
+}
+
+// ErrConflict signals that the requested action can't be performed because
+// of the current state of the target resource.
+type ErrConflict interface {
+	Conflict()
+}
+
+// ErrNotImplemented signals that the requested action/feature is not
+// implemented on the system as configured.
+type ErrNotImplemented interface {
+	NotImplemented()
+}
+
+// ErrSystem signals an internal error, e.g. a runtime or OS-level failure.
+type ErrSystem interface {
+	System()
+}
@@ -0,0 +1,96 @@
+package errdefs // import "github.com/docker/docker/api/errdefs"
+
+type errInvalidParameter struct {
+	error
+}
+
+func (errInvalidParameter) InvalidParameter() {}
+
+func (e errInvalidParameter) Cause() error {
+	return e.error
+}
+
+func (e errInvalidParameter) Unwrap() error {
+	return e.error
+}
+
+// InvalidParameter wraps the given error such that it satisfies
+// ErrInvalidParameter, and should be returned when the user input is
+// invalid, e.g. a bad request value on an update.
+func InvalidParameter(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errInvalidParameter{err}
+}
+
+type errConflict struct {
+	error
+}
+
+func (errConflict) Conflict() {}
+
+func (e errConflict) Cause() error {
+	return e.error
+}
+
+func (e errConflict) Unwrap() error {
+	return e.error
+}
+
+// Conflict wraps the given error such that it satisfies ErrConflict, and
+// should be returned when an operation can't proceed because of a conflict
+// with the current state of the target resource.
+func Conflict(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errConflict{err}
+}
+
+type errNotImplemented struct {
+	error
+}
+
+func (errNotImplemented) NotImplemented() {}
+
+func (e errNotImplemented) Cause() error {
+	return e.error
+}
+
+func (e errNotImplemented) Unwrap() error {
+	return e.error
+}
+
+// NotImplemented wraps the given error such that it satisfies
+// ErrNotImplemented, and should be returned when the requested action isn't
+// supported on the system as configured.
+func NotImplemented(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errNotImplemented{err}
+}
+
+type errSystem struct {
+	error
+}
+
+func (errSystem) System() {}
+
+func (e errSystem) Cause() error {
+	return e.error
+}
+
+func (e errSystem) Unwrap() error {
+	return e.error
+}
+
+// System wraps the given error such that it satisfies ErrSystem, and should
+// be returned for internal errors that the caller can't act on directly.
+func System(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errSystem{err}
+}
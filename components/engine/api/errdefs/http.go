@@ -0,0 +1,25 @@
+package errdefs // import "github.com/docker/docker/api/errdefs"
+
+import "net/http"
+
+// GetHTTPErrorStatusCode retrieves the correct HTTP status code for the
+// given error, so the HTTP layer doesn't need to substring-match error
+// messages to pick a status.
+func GetHTTPErrorStatusCode(err error) int {
+	if err == nil {
+		return http.StatusInternalServerError
+	}
+
+	switch {
+	case IsInvalidParameter(err):
+		return http.StatusBadRequest
+	case IsConflict(err):
+		return http.StatusConflict
+	case IsNotImplemented(err):
+		return http.StatusNotImplemented
+	case IsSystem(err):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,56 @@
+package errdefs // import "github.com/docker/docker/api/errdefs"
+
+type causer interface {
+	Cause() error
+}
+
+// getImplementer walks the Cause()/Unwrap() chain of an error looking for
+// one that implements iface, returning the last error in the chain if none
+// do. It
+// lets a deeply-wrapped error (e.g. returned across several package
+// boundaries) still be classified by the Is* helpers below.
+func getImplementer(err error) error {
+	switch e := err.(type) {
+	case
+		ErrNotFound,
+		ErrInvalidParameter,
+		ErrConflict,
+		ErrNotImplemented,
+		ErrSystem:
+		return err
+	case causer:
+		return getImplementer(e.Cause())
+	case interface{ Unwrap() error }:
+		return getImplementer(e.Unwrap())
+	default:
+		return err
+	}
+}
+
+// IsInvalidParameter returns true if the passed in error is an
+// ErrInvalidParameter, or wraps one.
+func IsInvalidParameter(err error) bool {
+	_, ok := getImplementer(err).(ErrInvalidParameter)
+	return ok
+}
+
+// IsConflict returns true if the passed in error is an ErrConflict, or
+// wraps one.
+func IsConflict(err error) bool {
+	_, ok := getImplementer(err).(ErrConflict)
+	return ok
+}
+
+// IsNotImplemented returns true if the passed in error is an
+// ErrNotImplemented, or wraps one.
+func IsNotImplemented(err error) bool {
+	_, ok := getImplementer(err).(ErrNotImplemented)
+	return ok
+}
+
+// IsSystem returns true if the passed in error is an ErrSystem, or wraps
+// one.
+func IsSystem(err error) bool {
+	_, ok := getImplementer(err).(ErrSystem)
+	return ok
+}
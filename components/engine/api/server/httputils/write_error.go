@@ -0,0 +1,27 @@
+package httputils // import "github.com/docker/docker/api/server/httputils"
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/docker/docker/api/errdefs"
+	"github.com/docker/docker/api/types"
+)
+
+// WriteError decodes a given error into a status code and body, and writes
+// the error to an HTTP ResponseWriter. Any error implementing one of the
+// api/errdefs interfaces is translated to the matching HTTP status code;
+// anything else falls back to 500 Internal Server Error.
+func WriteError(w http.ResponseWriter, err error) error {
+	if err == nil || w == nil {
+		return nil
+	}
+
+	statusCode := errdefs.GetHTTPErrorStatusCode(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	return json.NewEncoder(w).Encode(&types.ErrorResponse{
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,27 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+import "errors"
+
+// Sentinel errors returned by the daemon for invalid resource updates.
+// Clients can match on these with errors.Is, and the daemon wraps them in
+// the appropriate api/errdefs category so the HTTP layer reports a precise
+// status code.
+var (
+	// ErrSwapLessThanMemory is returned when a memory update would make the
+	// container's memory limit exceed its already-configured swap limit,
+	// without the swap limit being updated at the same time.
+	ErrSwapLessThanMemory = errors.New("memory limit should be smaller than already set memoryswap limit, update the memoryswap at the same time")
+
+	// ErrMemorySwappinessOutOfRange is returned when MemorySwappiness is set
+	// outside the valid 0-100 range.
+	ErrMemorySwappinessOutOfRange = errors.New("memory swappiness must be between 0 and 100")
+
+	// ErrCPUQuotaWithoutPeriod is returned when CPUQuota is set without a
+	// corresponding CPUPeriod, which the kernel rejects.
+	ErrCPUQuotaWithoutPeriod = errors.New("CPU cfs quota cannot be set without a CPU cfs period")
+
+	// ErrKernelMemoryUpdateUnsupported is returned when updating kernel
+	// memory is requested on a host whose kernel doesn't support changing
+	// the limit of a running container.
+	ErrKernelMemoryUpdateUnsupported = errors.New("kernel memory limit is not supported for running containers on this host")
+)
@@ -0,0 +1,32 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+import "time"
+
+// HealthConfig holds configuration settings for the HEALTHCHECK feature.
+type HealthConfig struct {
+	// Test is the test to perform to check that the container is healthy.
+	// An empty slice means to inherit the default.
+	// The options are:
+	// {} : inherit healthcheck
+	// {"NONE"} : disable healthcheck
+	// {"CMD", args...} : exec arguments directly
+	// {"CMD-SHELL", command} : run command with system's default shell
+	Test []string `json:",omitempty"`
+
+	// Interval is the time to wait between checks. Zero means inherit.
+	Interval time.Duration `json:",omitempty"`
+
+	// Timeout is the time to wait before considering the check to have
+	// hung. Zero means inherit.
+	Timeout time.Duration `json:",omitempty"`
+
+	// StartPeriod is the time to wait after container start before running
+	// the first check, to give slow-starting containers time to get
+	// going before the probe's failures count against the retries.
+	// Zero means inherit.
+	StartPeriod time.Duration `json:",omitempty"`
+
+	// Retries is the number of consecutive failures needed to consider a
+	// container as unhealthy. Zero means inherit.
+	Retries int `json:",omitempty"`
+}
@@ -0,0 +1,46 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+// Resources contains container's resources (cgroups config, ulimits...)
+type Resources struct {
+	// Applicable to all platforms
+	CPUShares int64 `json:"CpuShares"` // CPU shares (relative weight vs. other containers)
+	Memory    int64 // Memory limit (in bytes)
+	NanoCPUs  int64 `json:"NanoCpus"` // CPU quota in units of 10<sup>-9</sup> CPUs.
+
+	// Applicable to UNIX platforms
+	CgroupParent         string // Parent cgroup.
+	BlkioWeight          uint16 // Block IO weight (relative weight vs. other containers)
+	CPUPeriod            int64  `json:"CpuPeriod"`          // CPU CFS (Completely Fair Scheduler) period
+	CPUQuota             int64  `json:"CpuQuota"`           // CPU CFS (Completely Fair Scheduler) quota
+	CPURealtimePeriod    int64  `json:"CpuRealtimePeriod"`  // CPU real-time period
+	CPURealtimeRuntime   int64  `json:"CpuRealtimeRuntime"` // CPU real-time runtime
+	CpusetCpus           string // CpusetCpus 0-2, 0,1
+	CpusetMems           string // CpusetMems 0-2, 0,1
+	KernelMemory         int64  `json:",omitempty"` // Kernel memory limit (in bytes)
+	MemoryReservation    int64  // Memory soft limit (in bytes)
+	MemorySwap           int64  // Total memory usage (memory + swap); set `-1` to enable unlimited swap
+	MemorySwappiness     *int64 // Tuning container memory swappiness behaviour
+	OomKillDisable       *bool  // Whether to disable OOM Killer or not
+	PidsLimit            *int64 // Setting PidsLimit is not supported on Windows
+}
+
+// RestartPolicy represents the restart policies of the container.
+type RestartPolicy struct {
+	Name              string
+	MaximumRetryCount int
+}
+
+// IsNone indicates whether the container has the "no" restart policy.
+// This means the container will not automatically restart when exiting.
+func (rp *RestartPolicy) IsNone() bool {
+	return rp.Name == "no" || rp.Name == ""
+}
+
+// HostConfig the non-portable Config structure of a container.
+// Note that this is a subset of the real config held by the daemon; only
+// the fields touched by ContainerUpdate are reproduced here.
+type HostConfig struct {
+	// Applicable to all platforms
+	Resources     // Resources contains container's resources (cgroups, ulimits)
+	RestartPolicy RestartPolicy // Restart policy to be used for the container
+}
@@ -0,0 +1,14 @@
+package container // import "github.com/docker/docker/api/types/container"
+
+// UpdateConfig holds the mutable attributes of a container that can be
+// changed in place via ContainerUpdate, without recreating the container.
+type UpdateConfig struct {
+	Resources
+	RestartPolicy RestartPolicy
+
+	// Healthcheck, if non-nil, replaces the container's current health
+	// check configuration. Setting Test to []string{"NONE"} disables the
+	// healthcheck monitor; setting it on a container that had no
+	// healthcheck starts one.
+	Healthcheck *HealthConfig `json:",omitempty"`
+}
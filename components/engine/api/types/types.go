@@ -0,0 +1,14 @@
+package types // import "github.com/docker/docker/api/types"
+
+// ContainerUpdateOKBody contains the response for the endpoint
+// POST /containers/{id}/update
+type ContainerUpdateOKBody struct {
+	// Warnings are any warnings encountered during container update.
+	Warnings []string
+}
+
+// ErrorResponse is the response body of API errors.
+type ErrorResponse struct {
+	// Message is the error message.
+	Message string `json:"message"`
+}
@@ -0,0 +1,79 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/docker/docker/api/errdefs"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+)
+
+// knownSentinelErrors are matched by message against the body of an API
+// error response, so that a caller can use errors.Is against the same
+// sentinel value the daemon validated against, even though the error
+// crossed the wire as a plain string.
+var knownSentinelErrors = []error{
+	containertypes.ErrSwapLessThanMemory,
+	containertypes.ErrMemorySwappinessOutOfRange,
+	containertypes.ErrCPUQuotaWithoutPeriod,
+	containertypes.ErrKernelMemoryUpdateUnsupported,
+}
+
+// errorFromStatusCode reconstructs an error from an API error response,
+// classifying it into the matching api/errdefs category (so
+// errdefs.IsInvalidParameter and friends work on the client), and
+// substituting a known sentinel when the message matches one exactly (so
+// errors.Is works for the specific cases the daemon documents).
+func errorFromStatusCode(message string, statusCode int) error {
+	var err error = errorMessage(message)
+	for _, sentinel := range knownSentinelErrors {
+		if sentinel.Error() == message {
+			err = sentinel
+			break
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return errdefs.InvalidParameter(err)
+	case statusCode == http.StatusConflict:
+		return errdefs.Conflict(err)
+	case statusCode == http.StatusNotImplemented:
+		return errdefs.NotImplemented(err)
+	case statusCode >= 500:
+		return errdefs.System(err)
+	default:
+		return err
+	}
+}
+
+type errorMessage string
+
+func (e errorMessage) Error() string {
+	return string(e)
+}
+
+// checkResponseErr is the response-decoding path every API call funnels
+// its HTTP response through: for a non-2xx response it reads the
+// types.ErrorResponse body and turns it into an error via
+// errorFromStatusCode, so callers get a typed api/errdefs error (and, for
+// the sentinels documented by the daemon, one errors.Is can match).
+func checkResponseErr(serverResp *http.Response) error {
+	if serverResp == nil || serverResp.StatusCode < 400 {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(serverResp.Body)
+	if err != nil {
+		return errorFromStatusCode(err.Error(), serverResp.StatusCode)
+	}
+
+	var errResp types.ErrorResponse
+	if jsonErr := json.Unmarshal(body, &errResp); jsonErr != nil || errResp.Message == "" {
+		return errorFromStatusCode(string(body), serverResp.StatusCode)
+	}
+
+	return errorFromStatusCode(errResp.Message, serverResp.StatusCode)
+}
@@ -0,0 +1,200 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
+)
+
+const (
+	defaultProbeInterval = 30 * time.Second
+	defaultProbeTimeout  = 30 * time.Second
+	defaultProbeRetries  = 3
+)
+
+// healthMonitors tracks the cancel function for each container's running
+// health-check probe loop, keyed by container ID, so updateHealthMonitor
+// can start, stop, or swap it out without restarting the container.
+var healthMonitors = struct {
+	sync.Mutex
+	byContainer map[string]context.CancelFunc
+}{byContainer: make(map[string]context.CancelFunc)}
+
+// updateHealthMonitor reconciles the health-check monitor for c with its
+// current Config.Healthcheck:
+//   - if c now has a healthcheck and had none (or had NONE) running, it
+//     starts the probe loop;
+//   - if the healthcheck was set to NONE, it stops any running loop and
+//     clears the reported health status;
+//   - otherwise, it restarts the loop with the new
+//     interval/timeout/retries/test/start-period, so the change takes
+//     effect without restarting the container.
+//
+// It is idempotent and safe to call any time c's health check
+// configuration changes while the container is running.
+func updateHealthMonitor(d *Daemon, c *containerpkg.Container) {
+	stopHealthMonitor(c.ID)
+
+	h := c.Config.Healthcheck
+	if h == nil || isNoHealthcheck(h) {
+		c.State.Lock()
+		hadHealth := c.State.Health != nil
+		c.State.Health = nil
+		c.State.Unlock()
+		if hadHealth {
+			d.LogContainerEvent(c, "health_status: none")
+		}
+		return
+	}
+
+	if !c.IsRunning() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	healthMonitors.Lock()
+	healthMonitors.byContainer[c.ID] = cancel
+	healthMonitors.Unlock()
+
+	go runHealthcheckLoop(ctx, d, c, h)
+}
+
+// stopHealthMonitor stops the probe loop for containerID, if one is
+// running. It is safe to call even when no monitor is running.
+func stopHealthMonitor(containerID string) {
+	healthMonitors.Lock()
+	cancel, ok := healthMonitors.byContainer[containerID]
+	delete(healthMonitors.byContainer, containerID)
+	healthMonitors.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+// runHealthcheckLoop probes c on h's configured interval, honouring
+// StartPeriod before the first probe counts against Retries, until ctx is
+// cancelled by a reconfiguration or the container stopping.
+func runHealthcheckLoop(ctx context.Context, d *Daemon, c *containerpkg.Container, h *containertypes.HealthConfig) {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = defaultProbeInterval
+	}
+	retries := h.Retries
+	if retries <= 0 {
+		retries = defaultProbeRetries
+	}
+
+	if h.StartPeriod > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(h.StartPeriod):
+		}
+	}
+
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failures := 0
+	status := "starting"
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !c.IsRunning() {
+			return
+		}
+
+		exitCode, _ := runHealthcheckProbe(ctx, d, c, h, timeout)
+		if ctx.Err() != nil {
+			// The monitor was stopped or reconfigured while the probe ran;
+			// its result no longer applies.
+			return
+		}
+		if exitCode == 0 {
+			failures = 0
+			status = "healthy"
+		} else {
+			failures++
+			// A failure only flips the reported status to "unhealthy" once
+			// the failing streak reaches Retries; until then the status is
+			// left as it was (e.g. an already-healthy container stays
+			// "healthy" through a transient failure).
+			if failures >= retries {
+				status = "unhealthy"
+			}
+		}
+		setContainerHealth(d, c, status, failures)
+	}
+}
+
+// setContainerHealth records status and failingStreak on c.State.Health,
+// guarded by c.State's own lock (the same lock the rest of the health
+// subsystem uses), and emits a health_status event on a status change.
+func setContainerHealth(d *Daemon, c *containerpkg.Container, status string, failingStreak int) {
+	c.State.Lock()
+	if c.State.Health == nil {
+		c.State.Health = &containerpkg.Health{}
+	}
+	changed := c.State.Health.Status != status
+	c.State.Health.Status = status
+	c.State.Health.FailingStreak = failingStreak
+	c.State.Unlock()
+
+	if changed {
+		d.LogContainerEvent(c, "health_status: "+status)
+	}
+}
+
+// runHealthcheckProbe execs h.Test inside c, bounded by timeout, and returns
+// its exit code and combined output. A probe that doesn't finish within
+// timeout, or that fails to even start, is reported as exit code 1, matching
+// the convention used when a HEALTHCHECK can't run at all.
+func runHealthcheckProbe(ctx context.Context, d *Daemon, c *containerpkg.Container, h *containertypes.HealthConfig, timeout time.Duration) (int, string) {
+	cmd := probeCommand(h.Test)
+	if cmd == nil {
+		return 0, ""
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	exitCode, output, err := d.containerd.Exec(probeCtx, c.ID, cmd)
+	if probeCtx.Err() == context.DeadlineExceeded {
+		return 1, fmt.Sprintf("Health check exceeded timeout (%s)", timeout)
+	}
+	if err != nil {
+		return 1, err.Error()
+	}
+	return exitCode, output
+}
+
+// probeCommand turns a HealthConfig.Test into the command to exec, per the
+// same {"NONE"}/{"CMD", ...}/{"CMD-SHELL", ...} convention used when a
+// container is created with a healthcheck. It returns nil for an empty or
+// NONE test.
+func probeCommand(test []string) []string {
+	if len(test) == 0 || test[0] == "NONE" {
+		return nil
+	}
+	if test[0] == "CMD-SHELL" {
+		return append([]string{"/bin/sh", "-c"}, strings.Join(test[1:], " "))
+	}
+	if test[0] == "CMD" {
+		return test[1:]
+	}
+	return test
+}
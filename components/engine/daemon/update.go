@@ -0,0 +1,184 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+// ContainerUpdate updates configuration of the container
+func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostConfig) (types.ContainerUpdateOKBody, error) {
+	var warnings []string
+
+	warnings, err := daemon.verifyContainerSettings(hostConfig, nil, true)
+	if err != nil {
+		return types.ContainerUpdateOKBody{Warnings: warnings}, err
+	}
+
+	if err := daemon.update(name, &container.UpdateConfig{
+		Resources:     hostConfig.Resources,
+		RestartPolicy: hostConfig.RestartPolicy,
+	}); err != nil {
+		return types.ContainerUpdateOKBody{Warnings: warnings}, err
+	}
+
+	return types.ContainerUpdateOKBody{Warnings: warnings}, nil
+}
+
+func (daemon *Daemon) update(name string, config *container.UpdateConfig) error {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	restoreConfig := false
+	backupHostConfig := *ctr.HostConfig
+	backupHealthcheck := ctr.Config.Healthcheck
+	defer func() {
+		if restoreConfig {
+			ctr.Lock()
+			ctr.HostConfig = &backupHostConfig
+			ctr.Config.Healthcheck = backupHealthcheck
+			ctr.CheckpointTo(daemon.containersReplica)
+			ctr.Unlock()
+		}
+	}()
+
+	ctr.Lock()
+
+	resources := config.Resources
+	cResources := &ctr.HostConfig.Resources
+
+	if err := daemon.validateUpdateResources(cResources, &resources); err != nil {
+		restoreConfig = true
+		ctr.Unlock()
+		return err
+	}
+
+	if resources.BlkioWeight != 0 {
+		cResources.BlkioWeight = resources.BlkioWeight
+	}
+	if resources.CPUShares != 0 {
+		cResources.CPUShares = resources.CPUShares
+	}
+	if resources.NanoCPUs != 0 {
+		cResources.NanoCPUs = resources.NanoCPUs
+	}
+	if resources.CPUPeriod != 0 {
+		cResources.CPUPeriod = resources.CPUPeriod
+	}
+	if resources.CPUQuota != 0 {
+		cResources.CPUQuota = resources.CPUQuota
+	}
+	if resources.CpusetCpus != "" {
+		cResources.CpusetCpus = resources.CpusetCpus
+	}
+	if resources.CpusetMems != "" {
+		cResources.CpusetMems = resources.CpusetMems
+	}
+	if resources.Memory != 0 {
+		cResources.Memory = resources.Memory
+	}
+	if resources.MemorySwap != 0 {
+		cResources.MemorySwap = resources.MemorySwap
+	}
+	if resources.MemoryReservation != 0 {
+		cResources.MemoryReservation = resources.MemoryReservation
+	}
+	if resources.PidsLimit != nil {
+		cResources.PidsLimit = resources.PidsLimit
+	}
+
+	if config.RestartPolicy.IsNone() {
+		config.RestartPolicy = ctr.HostConfig.RestartPolicy
+	}
+	ctr.HostConfig.RestartPolicy = config.RestartPolicy
+
+	healthCheckChanged := config.Healthcheck != nil
+	if healthCheckChanged {
+		ctr.Config.Healthcheck = config.Healthcheck
+	}
+
+	ctr.Unlock()
+
+	if err := ctr.CheckpointTo(daemon.containersReplica); err != nil {
+		restoreConfig = true
+		return err
+	}
+
+	if healthCheckChanged {
+		// Start, stop, or reconfigure the running healthcheck monitor to
+		// match the new config, without restarting the container.
+		updateHealthMonitor(daemon, ctr)
+	}
+
+	// if Restart Policy changed, we need to update container monitor
+	ctr.UpdateMonitor(config.RestartPolicy)
+
+	// if container is not running, update hostConfig struct is enough,
+	// resources will be updated when the container is started again
+	if !ctr.IsRunning() {
+		return nil
+	}
+
+	// if container is running, we need to update configs to the real world.
+	if err := daemon.containerd.UpdateResources(context.Background(), ctr.ID, toContainerdResources(resources)); err != nil {
+		restoreConfig = true
+		return errCannotUpdate(ctr.ID, err)
+	}
+
+	return nil
+}
+
+func errCannotUpdate(containerID string, err error) error {
+	return fmt.Errorf("Cannot update container %s: %v", containerID, err)
+}
+
+// isNoHealthcheck reports whether a HealthConfig explicitly disables the
+// healthcheck, i.e. Test is set to {"NONE"}.
+func isNoHealthcheck(healthcheck *container.HealthConfig) bool {
+	return len(healthcheck.Test) == 1 && healthcheck.Test[0] == "NONE"
+}
+
+// validateUpdateResources checks a requested resource update against the
+// container's current resources and the host's capabilities, returning a
+// typed api/errdefs error for anything the update can't satisfy. Where the
+// container package defines a sentinel error for the case, that sentinel
+// is the wrapped error.
+func (daemon *Daemon) validateUpdateResources(current *container.Resources, update *container.Resources) error {
+	if update.Memory < 0 && update.Memory != -1 {
+		return errdefs.InvalidParameter(fmt.Errorf("invalid memory limit %d", update.Memory))
+	}
+
+	// if memory limit larger than already set memoryswap limit and doesn't
+	// update the memoryswap limit at the same time, then error out.
+	if update.Memory != 0 && update.Memory > current.MemorySwap && update.MemorySwap == 0 {
+		return errdefs.InvalidParameter(container.ErrSwapLessThanMemory)
+	}
+
+	if update.MemorySwappiness != nil {
+		swappiness := *update.MemorySwappiness
+		if swappiness < 0 || swappiness > 100 {
+			return errdefs.InvalidParameter(container.ErrMemorySwappinessOutOfRange)
+		}
+	}
+
+	if update.CPUQuota != 0 && update.CPUQuota != -1 {
+		period := update.CPUPeriod
+		if period == 0 {
+			period = current.CPUPeriod
+		}
+		if period == 0 {
+			return errdefs.InvalidParameter(container.ErrCPUQuotaWithoutPeriod)
+		}
+	}
+
+	if update.KernelMemory != 0 && !daemon.RawSysInfo().KernelMemory {
+		return errdefs.NotImplemented(container.ErrKernelMemoryUpdateUnsupported)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// toContainerdResources converts the update resources requested by the user
+// into the containerd resources format, so that they can be applied to a
+// running container via (containerd).UpdateResources.
+func toContainerdResources(resources container.Resources) *specs.LinuxResources {
+	var r specs.LinuxResources
+
+	r.Memory = getMemoryResources(resources)
+	r.CPU = getCPUResources(resources)
+	r.Pids = getPidsLimit(resources)
+
+	return &r
+}
+
+func getMemoryResources(resources container.Resources) *specs.LinuxMemory {
+	var memory specs.LinuxMemory
+
+	if resources.Memory != 0 {
+		memory.Limit = &resources.Memory
+	}
+
+	if resources.MemoryReservation != 0 {
+		memory.Reservation = &resources.MemoryReservation
+	}
+
+	if resources.MemorySwap > 0 {
+		memory.Swap = &resources.MemorySwap
+	}
+
+	if resources.MemorySwappiness != nil {
+		swappiness := uint64(*resources.MemorySwappiness)
+		memory.Swappiness = &swappiness
+	}
+
+	if resources.KernelMemory != 0 {
+		memory.Kernel = &resources.KernelMemory
+	}
+
+	return &memory
+}
+
+func getCPUResources(resources container.Resources) *specs.LinuxCPU {
+	var cpu specs.LinuxCPU
+
+	if resources.CPUShares != 0 {
+		shares := uint64(resources.CPUShares)
+		cpu.Shares = &shares
+	}
+
+	if resources.CpusetCpus != "" {
+		cpu.Cpus = resources.CpusetCpus
+	}
+
+	if resources.CpusetMems != "" {
+		cpu.Mems = resources.CpusetMems
+	}
+
+	var (
+		period uint64
+		quota  int64
+	)
+	if resources.NanoCPUs > 0 {
+		// Convert NanoCPUs to CPU Period/Quota on a 100ms period, in line
+		// with daemon/daemon_unix.go's verifyPlatformContainerSettings.
+		period = uint64(100 * time.Millisecond / time.Microsecond)
+		quota = int64(resources.NanoCPUs) * int64(period) / 1e9
+	}
+
+	// The caller may have set CPUQuota/CPUPeriod explicitly on the update,
+	// without setting NanoCPUs (or with NanoCPUs left at zero to preserve
+	// the container's current value). Don't let those be silently dropped.
+	// CPUQuota of -1 is a valid value meaning "unlimited", so it must be
+	// kept as a signed int64, unlike Period which has no such sentinel.
+	if quota == 0 && resources.CPUQuota != 0 {
+		quota = resources.CPUQuota
+	}
+	if period == 0 && resources.CPUPeriod != 0 {
+		period = uint64(resources.CPUPeriod)
+	}
+
+	if period != 0 {
+		cpu.Period = &period
+	}
+	if quota != 0 {
+		cpu.Quota = &quota
+	}
+
+	if resources.CPURealtimePeriod != 0 {
+		period := uint64(resources.CPURealtimePeriod)
+		cpu.RealtimePeriod = &period
+	}
+
+	if resources.CPURealtimeRuntime != 0 {
+		runtime := resources.CPURealtimeRuntime
+		cpu.RealtimeRuntime = &runtime
+	}
+
+	return &cpu
+}
+
+func getPidsLimit(resources container.Resources) *specs.LinuxPids {
+	if resources.PidsLimit == nil {
+		return nil
+	}
+	return &specs.LinuxPids{Limit: *resources.PidsLimit}
+}
@@ -1,21 +1,20 @@
 package container // import "github.com/docker/docker/integration/container"
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/errdefs"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
+	icontainer "github.com/docker/docker/integration/internal/container"
 	"github.com/docker/docker/integration/internal/request"
-	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/gotestyourself/gotestyourself/poll"
 	"github.com/gotestyourself/gotestyourself/skip"
 	"github.com/stretchr/testify/assert"
@@ -73,6 +72,159 @@ func TestUpdateMemory(t *testing.T) {
 	assert.Equal(t, strings.TrimSpace(body), "524288000")
 }
 
+func TestUpdateHealthcheck(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+
+	defer setupTest(t)()
+	apiClient := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	c, err := apiClient.ContainerCreate(ctx,
+		&container.Config{
+			Cmd:   []string{"top"},
+			Image: "busybox",
+		},
+		nil,
+		nil,
+		"",
+	)
+	require.NoError(t, err)
+
+	err = apiClient.ContainerStart(ctx, c.ID, types.ContainerStartOptions{})
+	require.NoError(t, err)
+	poll.WaitOn(t, containerIsInState(ctx, apiClient, c.ID, "running"), poll.WithDelay(100*time.Millisecond))
+
+	inspect, err := apiClient.ContainerInspect(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Nil(t, inspect.State.Health)
+
+	_, err = apiClient.ContainerUpdate(ctx, c.ID, container.UpdateConfig{
+		Healthcheck: &container.HealthConfig{
+			Test:     []string{"CMD-SHELL", "exit 1"},
+			Interval: 100 * time.Millisecond,
+			Timeout:  100 * time.Millisecond,
+			Retries:  1,
+		},
+	})
+	require.NoError(t, err)
+
+	poll.WaitOn(t, pollForHealthStatus(ctx, apiClient, c.ID, "unhealthy"), poll.WithTimeout(30*time.Second))
+
+	_, err = apiClient.ContainerUpdate(ctx, c.ID, container.UpdateConfig{
+		Healthcheck: &container.HealthConfig{
+			Test:     []string{"CMD-SHELL", "exit 0"},
+			Interval: 100 * time.Millisecond,
+			Timeout:  100 * time.Millisecond,
+			Retries:  1,
+		},
+	})
+	require.NoError(t, err)
+
+	poll.WaitOn(t, pollForHealthStatus(ctx, apiClient, c.ID, "healthy"), poll.WithTimeout(30*time.Second))
+
+	_, err = apiClient.ContainerUpdate(ctx, c.ID, container.UpdateConfig{
+		Healthcheck: &container.HealthConfig{
+			Test: []string{"NONE"},
+		},
+	})
+	require.NoError(t, err)
+
+	inspect, err = apiClient.ContainerInspect(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Nil(t, inspect.State.Health)
+}
+
+// pollForHealthStatus polls for the health status of a container to match
+// the given status. It's parsed the same way TestHealth (integration/container)
+// reads the status: a simple string comparison against inspect.State.Health.Status.
+func pollForHealthStatus(ctx context.Context, apiClient client.APIClient, containerID, healthStatus string) func(log poll.LogT) poll.Result {
+	return func(log poll.LogT) poll.Result {
+		inspect, err := apiClient.ContainerInspect(ctx, containerID)
+		switch {
+		case err != nil:
+			return poll.Error(err)
+		case inspect.State.Health == nil:
+			return poll.Continue("waiting for healthcheck to be configured")
+		case inspect.State.Health.Status == healthStatus:
+			return poll.Success()
+		default:
+			return poll.Continue("waiting for status to be '%s', currently: '%s'", healthStatus, inspect.State.Health.Status)
+		}
+	}
+}
+
+func TestUpdateRejectsInvalidResources(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType != "linux")
+
+	defer setupTest(t)()
+	apiClient := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	c, err := apiClient.ContainerCreate(ctx,
+		&container.Config{
+			Cmd:   []string{"top"},
+			Image: "busybox",
+		},
+		&container.HostConfig{
+			Resources: container.Resources{
+				Memory:     200 * 1024 * 1024,
+				MemorySwap: 400 * 1024 * 1024,
+			},
+		},
+		nil,
+		"",
+	)
+	require.NoError(t, err)
+
+	err = apiClient.ContainerStart(ctx, c.ID, types.ContainerStartOptions{})
+	require.NoError(t, err)
+	poll.WaitOn(t, containerIsInState(ctx, apiClient, c.ID, "running"), poll.WithDelay(100*time.Millisecond))
+
+	for _, tc := range []struct {
+		desc    string
+		update  container.UpdateConfig
+		wantErr error
+	}{
+		{
+			desc: "swap smaller than memory",
+			update: container.UpdateConfig{
+				Resources: container.Resources{
+					Memory:     500 * 1024 * 1024,
+					MemorySwap: 0,
+				},
+			},
+			wantErr: container.ErrSwapLessThanMemory,
+		},
+		{
+			desc: "negative memory",
+			update: container.UpdateConfig{
+				Resources: container.Resources{
+					Memory: -2,
+				},
+			},
+		},
+		{
+			desc: "quota without period",
+			update: container.UpdateConfig{
+				Resources: container.Resources{
+					CPUQuota:  20000,
+					CPUPeriod: 0,
+				},
+			},
+			wantErr: container.ErrCPUQuotaWithoutPeriod,
+		},
+	} {
+		_, err := apiClient.ContainerUpdate(ctx, c.ID, tc.update)
+		if !assert.Error(t, err, tc.desc) {
+			continue
+		}
+		assert.True(t, errdefs.IsInvalidParameter(err), "%s: expected an invalid parameter error, got: %v", tc.desc, err)
+		if tc.wantErr != nil {
+			assert.True(t, errors.Is(err, tc.wantErr), "%s: expected error to match %v, got: %v", tc.desc, tc.wantErr, err)
+		}
+	}
+}
+
 func TestUpdateCPUQUota(t *testing.T) {
 	t.Parallel()
 
@@ -122,75 +274,110 @@ func TestUpdateCPUQUota(t *testing.T) {
 			t.Fatalf("quota not updated in the API, expected %d, got: %d", test.update, inspect.HostConfig.CPUQuota)
 		}
 
-		execCreate, err := client.ContainerExecCreate(ctx, c.ID, types.ExecConfig{
-			Cmd:          []string{"/bin/cat", "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"},
-			AttachStdout: true,
-			AttachStderr: true,
-		})
+		result, err := icontainer.Exec(ctx, client, c.ID, []string{"/bin/cat", "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"})
 		if err != nil {
 			t.Fatal(err)
 		}
+		if result.ExitCode != 0 {
+			t.Fatalf("cat cpu.cfs_quota_us exited %d: %s", result.ExitCode, result.Stderr())
+		}
 
-		attach, err := client.ContainerExecAttach(ctx, execCreate.ID, types.ExecStartCheck{})
-		if err != nil {
-			t.Fatal(err)
+		actual := strings.TrimSpace(result.Combined())
+		if actual != strconv.Itoa(int(test.update)) {
+			t.Fatalf("expected cgroup value %d, got: %s", test.update, actual)
+		}
+	}
+
+}
+
+func TestUpdateCPUPeriodAndQuota(t *testing.T) {
+	t.Parallel()
+
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	c, err := client.ContainerCreate(ctx, &container.Config{
+		Image: "busybox",
+		Cmd:   []string{"top"},
+	}, nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := client.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			panic(fmt.Sprintf("failed to clean up after test: %v", err))
+		}
+	}()
+
+	if err := client.ContainerStart(ctx, c.ID, types.ContainerStartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		desc          string
+		period, quota int64
+	}{
+		{desc: "set period only", period: 100000, quota: 0},
+		{desc: "set quota only", period: 0, quota: 20000},
+		{desc: "set both period and quota", period: 150000, quota: 30000},
+		{desc: "update period only, preserve quota", period: 200000, quota: 0},
+		{desc: "update quota only, preserve period", period: 0, quota: 40000},
+		{desc: "unset quota, preserve period", period: 0, quota: -1},
+	} {
+		if _, err := client.ContainerUpdate(ctx, c.ID, container.UpdateConfig{
+			Resources: container.Resources{
+				CPUPeriod: test.period,
+				CPUQuota:  test.quota,
+			},
+		}); err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
 		}
 
-		if err := client.ContainerExecStart(ctx, execCreate.ID, types.ExecStartCheck{}); err != nil {
+		inspect, err := client.ContainerInspect(ctx, c.ID)
+		if err != nil {
 			t.Fatal(err)
 		}
 
-		buf := bytes.NewBuffer(nil)
-		ready := make(chan error)
+		if test.period != 0 && inspect.HostConfig.CPUPeriod != test.period {
+			t.Fatalf("%s: period not updated in the API, expected %d, got: %d", test.desc, test.period, inspect.HostConfig.CPUPeriod)
+		}
+		if test.quota != 0 && inspect.HostConfig.CPUQuota != test.quota {
+			t.Fatalf("%s: quota not updated in the API, expected %d, got: %d", test.desc, test.quota, inspect.HostConfig.CPUQuota)
+		}
 
-		go func() {
-			_, err := stdcopy.StdCopy(buf, buf, attach.Reader)
-			ready <- err
-		}()
+		// A quota that was never set is 0 in the API but reads back from the
+		// cgroup as -1 (no limit).
+		wantQuota := inspect.HostConfig.CPUQuota
+		if wantQuota == 0 {
+			wantQuota = -1
+		}
 
-		select {
-		case <-time.After(60 * time.Second):
-			t.Fatal("timeout waiting for exec to complete")
-		case err := <-ready:
+		for _, cg := range []struct {
+			path string
+			want int64
+		}{
+			{path: "/sys/fs/cgroup/cpu/cpu.cfs_period_us", want: inspect.HostConfig.CPUPeriod},
+			{path: "/sys/fs/cgroup/cpu/cpu.cfs_quota_us", want: wantQuota},
+		} {
+			body, err := getContainerSysFSValue(ctx, client, c.ID, cg.path)
 			if err != nil {
-				t.Fatal(err)
+				t.Fatalf("%s: %v", test.desc, err)
+			}
+			actual := strings.TrimSpace(body)
+			if actual != strconv.FormatInt(cg.want, 10) {
+				t.Fatalf("%s: expected cgroup value %s to be %d, got: %s", test.desc, cg.path, cg.want, actual)
 			}
-		}
-
-		actual := strings.TrimSpace(buf.String())
-		if actual != strconv.Itoa(int(test.update)) {
-			t.Fatalf("expected cgroup value %d, got: %s", test.update, actual)
 		}
 	}
-
 }
 
 func getContainerSysFSValue(ctx context.Context, client client.APIClient, cID string, path string) (string, error) {
-	var b bytes.Buffer
-
-	ex, err := client.ContainerExecCreate(ctx, cID,
-		types.ExecConfig{
-			AttachStdout: true,
-			Cmd:          strslice.StrSlice([]string{"cat", path}),
-		},
-	)
+	result, err := icontainer.Exec(ctx, client, cID, []string{"cat", path})
 	if err != nil {
 		return "", err
 	}
-
-	resp, err := client.ContainerExecAttach(ctx, ex.ID,
-		types.ExecStartCheck{
-			Detach: false,
-			Tty:    false,
-		},
-	)
-	if err != nil {
-		return "", err
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("cat %s exited %d: %s", path, result.ExitCode, result.Stderr())
 	}
-
-	defer resp.Close()
-
-	b.Reset()
-	_, err = stdcopy.StdCopy(&b, ioutil.Discard, resp.Reader)
-	return b.String(), err
+	return result.Stdout(), nil
 }
@@ -0,0 +1,145 @@
+package container // import "github.com/docker/docker/integration/internal/container"
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+)
+
+// ExecResult is the result of running an Exec: its exit code along with
+// the demultiplexed stdout/stderr it produced.
+type ExecResult struct {
+	ExitCode int
+
+	outBuffer *bytes.Buffer
+	errBuffer *bytes.Buffer
+}
+
+// Stdout returns the stdout produced by the exec.
+func (res *ExecResult) Stdout() string {
+	return res.outBuffer.String()
+}
+
+// Stderr returns the stderr produced by the exec.
+func (res *ExecResult) Stderr() string {
+	return res.errBuffer.String()
+}
+
+// Combined returns the stdout followed by the stderr as a single string;
+// output of the two streams is not interleaved in the order it was written.
+func (res *ExecResult) Combined() string {
+	return res.outBuffer.String() + res.errBuffer.String()
+}
+
+// ExecOption is a functional option for configuring a call to Exec.
+type ExecOption func(*execOptions)
+
+type execOptions struct {
+	user       string
+	privileged bool
+	env        []string
+	workingDir string
+	tty        bool
+}
+
+// WithUser sets the user (and optionally group) the exec runs as.
+func WithUser(user string) ExecOption {
+	return func(o *execOptions) {
+		o.user = user
+	}
+}
+
+// WithPrivileged sets whether the exec is run in privileged mode.
+func WithPrivileged(privileged bool) ExecOption {
+	return func(o *execOptions) {
+		o.privileged = privileged
+	}
+}
+
+// WithEnv sets the environment variables for the exec.
+func WithEnv(env []string) ExecOption {
+	return func(o *execOptions) {
+		o.env = env
+	}
+}
+
+// WithWorkingDir sets the working directory for the exec.
+func WithWorkingDir(workingDir string) ExecOption {
+	return func(o *execOptions) {
+		o.workingDir = workingDir
+	}
+}
+
+// WithTTY sets whether the exec attaches with a pseudo-TTY.
+func WithTTY(tty bool) ExecOption {
+	return func(o *execOptions) {
+		o.tty = tty
+	}
+}
+
+// Exec runs cmd inside containerID, synchronously, with stdin closed, and
+// returns its demultiplexed output along with its exit code.
+func Exec(ctx context.Context, apiClient client.APIClient, containerID string, cmd []string, opts ...ExecOption) (ExecResult, error) {
+	options := execOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	execConfig := types.ExecConfig{
+		Cmd:          cmd,
+		User:         options.user,
+		Privileged:   options.privileged,
+		Env:          options.env,
+		WorkingDir:   options.workingDir,
+		Tty:          options.tty,
+		AttachStdout: true,
+		AttachStderr: true,
+		AttachStdin:  false,
+	}
+
+	create, err := apiClient.ContainerExecCreate(ctx, containerID, execConfig)
+	if err != nil {
+		return ExecResult{}, errors.Wrap(err, "failed to create exec")
+	}
+
+	attach, err := apiClient.ContainerExecAttach(ctx, create.ID, types.ExecStartCheck{
+		Tty: options.tty,
+	})
+	if err != nil {
+		return ExecResult{}, errors.Wrap(err, "failed to attach to exec")
+	}
+	defer attach.Close()
+
+	var outBuf, errBuf bytes.Buffer
+	if options.tty {
+		// With a TTY, stdout and stderr are not multiplexed.
+		_, err = outBuf.ReadFrom(attach.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(&outBuf, &errBuf, attach.Reader)
+	}
+	if err != nil {
+		return ExecResult{}, errors.Wrap(err, "failed to read exec output")
+	}
+
+	inspect, err := apiClient.ContainerExecInspect(ctx, create.ID)
+	if err != nil {
+		return ExecResult{}, errors.Wrap(err, "failed to inspect exec")
+	}
+
+	return ExecResult{
+		ExitCode:  inspect.ExitCode,
+		outBuffer: &outBuf,
+		errBuffer: &errBuf,
+	}, nil
+}
+
+// TrimmedString is a convenience helper for tests that just want a
+// whitespace-trimmed view of the exec's combined output.
+func (res *ExecResult) TrimmedString() string {
+	return strings.TrimSpace(res.Combined())
+}